@@ -0,0 +1,23 @@
+package field
+
+// sensitive marks a value for unconditional redaction by the log
+// package's redaction pipeline, regardless of the field's key.
+type sensitive struct {
+	value interface{}
+}
+
+// Sensitive wraps v so it is always redacted wherever it's logged,
+// regardless of the field's key it ends up under.
+func Sensitive(v interface{}) interface{} {
+	return sensitive{value: v}
+}
+
+// Unwrap reports whether v was produced by Sensitive and, if so, returns
+// the value it wraps.
+func Unwrap(v interface{}) (interface{}, bool) {
+	s, ok := v.(sensitive)
+	if !ok {
+		return nil, false
+	}
+	return s.value, true
+}
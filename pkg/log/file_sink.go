@@ -0,0 +1,154 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Names of environment variables to configure the file sink.
+const (
+	LoggingFilePathEnv     = "LOGGING_FILE_PATH"
+	LoggingFileMaxBytesEnv = "LOGGING_FILE_MAX_BYTES"
+)
+
+// reopenableFile wraps an *os.File so that the underlying inode can be
+// swapped out from under concurrent writers, letting tools like logrotate
+// rotate the file on disk without the process ever writing to a deleted
+// inode or losing bytes mid-write.
+type reopenableFile struct {
+	path     string
+	maxBytes int64
+	mu       sync.Mutex
+	file     *os.File
+	written  int64
+}
+
+// newReopenableFile opens path for appending and, if maxBytes is positive,
+// rotates to a timestamped backup whenever the file grows past it.
+func newReopenableFile(path string, maxBytes int64) (*reopenableFile, error) {
+	f := &reopenableFile{path: path, maxBytes: maxBytes}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	registerSIGHUPHandler(f)
+	return f, nil
+}
+
+func (f *reopenableFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to open log file %s", f.path)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close() //nolint:errcheck
+		return errors.Wrapf(err, "Unable to stat log file %s", f.path)
+	}
+	f.file = file
+	f.written = info.Size()
+	return nil
+}
+
+// Write implements io.Writer. It is safe for concurrent use and rotates
+// the underlying file first if a size-based threshold has been exceeded.
+func (f *reopenableFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxBytes > 0 && f.written+int64(len(p)) > f.maxBytes {
+		if err := f.reopenLocked(true); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.written += int64(n)
+	return n, err
+}
+
+// Reopen closes and reopens the underlying file, picking up whatever now
+// exists at path. It is safe to call concurrently with Write and is what
+// the SIGHUP handler invokes.
+func (f *reopenableFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reopenLocked(false)
+}
+
+// reopenLocked must be called with f.mu held. When rename is true, the
+// current file is first moved aside with a timestamped suffix so the
+// size-based fallback never clobbers existing rotated logs.
+func (f *reopenableFile) reopenLocked(rename bool) error {
+	old := f.file
+	if rename {
+		backup := fmt.Sprintf("%s.%s", f.path, time.Now().UTC().Format("20060102T150405.000000000"))
+		if err := os.Rename(f.path, backup); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "Unable to rotate log file %s", f.path)
+		}
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	if old != nil {
+		old.Close() //nolint:errcheck
+	}
+	return nil
+}
+
+// sighupOnce guards the single process-wide SIGHUP subscription shared by
+// every reopenableFile; signal.Notify and its dispatcher goroutine must
+// only ever be registered once, no matter how many times SetOutput(FileSink)
+// is called.
+var (
+	sighupOnce   sync.Once
+	activeFileMu sync.Mutex
+	activeFile   *reopenableFile
+)
+
+// registerSIGHUPHandler marks f as the file that SIGHUP should reopen and,
+// the first time it's called for the process, starts the single dispatcher
+// goroutine that listens for SIGHUP and reopens whichever file is current.
+// External tools (logrotate) are expected to send SIGHUP after moving the
+// file aside.
+func registerSIGHUPHandler(f *reopenableFile) {
+	activeFileMu.Lock()
+	activeFile = f
+	activeFileMu.Unlock()
+
+	sighupOnce.Do(func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGHUP)
+		go func() {
+			for range sigCh {
+				activeFileMu.Lock()
+				current := activeFile
+				activeFileMu.Unlock()
+				if current == nil {
+					continue
+				}
+				if err := current.Reopen(); err != nil {
+					Error().WithError(err).Print("Failed to reopen log file on SIGHUP")
+				}
+			}
+		}()
+	})
+}
+
+func fileSinkMaxBytes() int64 {
+	v, ok := os.LookupEnv(LoggingFileMaxBytesEnv)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,105 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestReopenableFileWritesSurviveReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kanister.log")
+
+	f, err := newReopenableFile(path, 0)
+	if err != nil {
+		t.Fatalf("newReopenableFile: %v", err)
+	}
+
+	const writers = 8
+	const linesPerWriter = 200
+
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for i := 0; i < writers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < linesPerWriter; j++ {
+				if _, err := f.Write([]byte("line\n")); err != nil {
+					t.Errorf("Write: %v", err)
+				}
+				if j == linesPerWriter/2 {
+					if err := f.Reopen(); err != nil {
+						t.Errorf("Reopen: %v", err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	got := len(data) / len("line\n")
+	want := writers * linesPerWriter
+	if got != want {
+		t.Errorf("expected %d lines written across the reopen, got %d", want, got)
+	}
+}
+
+func TestRegisterSIGHUPHandlerOnlyNotifiesOnce(t *testing.T) {
+	dir := t.TempDir()
+
+	f1, err := newReopenableFile(filepath.Join(dir, "one.log"), 0)
+	if err != nil {
+		t.Fatalf("newReopenableFile: %v", err)
+	}
+	f2, err := newReopenableFile(filepath.Join(dir, "two.log"), 0)
+	if err != nil {
+		t.Fatalf("newReopenableFile: %v", err)
+	}
+
+	activeFileMu.Lock()
+	current := activeFile
+	activeFileMu.Unlock()
+
+	if current != f2 {
+		t.Error("expected the most recently registered file to become the active SIGHUP target")
+	}
+	_ = f1
+}
+
+func TestReopenableFileRotatesPastMaxBytes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kanister.log")
+
+	f, err := newReopenableFile(path, 10)
+	if err != nil {
+		t.Fatalf("newReopenableFile: %v", err)
+	}
+
+	if _, err := f.Write([]byte("0123456789")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := f.Write([]byte("rotated")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected exactly one rotated backup, got %v", matches)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != "rotated" {
+		t.Errorf("expected current file to contain only the post-rotation write, got %q", data)
+	}
+}
@@ -0,0 +1,127 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Names of environment variables to configure the Fluentbit forwarder.
+const (
+	LoggingFluentbitQueueCapacityEnv  = "LOGGING_FLUENTBIT_QUEUE_CAPACITY"
+	LoggingFluentbitOverflowPolicyEnv = "LOGGING_FLUENTBIT_OVERFLOW_POLICY"
+)
+
+const defaultFluentbitDialTimeout = 5 * time.Second
+
+// FluentbitHook is a logrus.Hook that forwards entries to Fluentbit
+// asynchronously. Fire only enqueues the already-formatted entry onto a
+// bounded channel; a shared asyncForwarder batches entries by count and
+// by flush interval and sends them over a reused TCP connection,
+// retrying with exponential backoff, so a slow or unreachable collector
+// never stalls the calling goroutine.
+type FluentbitHook struct {
+	fw *asyncForwarder[[]byte]
+}
+
+// NewFluentbitHook starts the background sender and returns a hook ready
+// to be registered via logrus.Logger.AddHook.
+func NewFluentbitHook(addr string) *FluentbitHook {
+	var conn net.Conn
+
+	send := func(batch [][]byte) error {
+		var buf bytes.Buffer
+		for _, p := range batch {
+			buf.Write(p)
+		}
+		if conn == nil {
+			c, err := net.DialTimeout("tcp", addr, defaultFluentbitDialTimeout)
+			if err != nil {
+				return err
+			}
+			conn = c
+		}
+		if _, err := conn.Write(buf.Bytes()); err != nil {
+			conn.Close() //nolint:errcheck
+			conn = nil
+			return err
+		}
+		return nil
+	}
+	closeConn := func() {
+		if conn != nil {
+			conn.Close() //nolint:errcheck
+		}
+	}
+
+	fw := newAsyncForwarder(asyncForwarderConfig[[]byte]{
+		capacity:   queueCapacityFromEnv(),
+		overflow:   overflowPolicyFromEnv(),
+		sendBatch:  send,
+		closeBatch: closeConn,
+	})
+	return &FluentbitHook{fw: fw}
+}
+
+// Levels implements logrus.Hook.
+func (h *FluentbitHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook. It preserves the existing entryToJSON wire
+// format and never blocks the caller except under the Block overflow
+// policy.
+func (h *FluentbitHook) Fire(entry *logrus.Entry) error {
+	payload := entryToJSON(entry)
+	if payload == nil {
+		return nil
+	}
+	h.fw.Enqueue(payload)
+	return nil
+}
+
+// Flush blocks until the queue has drained or ctx is done, so callers can
+// wait for in-flight entries to ship before the process exits.
+func (h *FluentbitHook) Flush(ctx context.Context) error {
+	return h.fw.Flush(ctx)
+}
+
+// Close stops the background sender without draining the queue; call
+// Flush first if pending entries must still be shipped.
+func (h *FluentbitHook) Close() {
+	h.fw.Close()
+}
+
+// Stats returns a snapshot of the hook's internal counters so higher
+// layers can surface them as Prometheus metrics.
+func (h *FluentbitHook) Stats() Stats {
+	return h.fw.Stats()
+}
+
+func queueCapacityFromEnv() int {
+	v, ok := os.LookupEnv(LoggingFluentbitQueueCapacityEnv)
+	if !ok {
+		return defaultAsyncQueueCapacity
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return defaultAsyncQueueCapacity
+	}
+	return n
+}
+
+func overflowPolicyFromEnv() OverflowPolicy {
+	switch os.Getenv(LoggingFluentbitOverflowPolicyEnv) {
+	case "DropNewest":
+		return DropNewest
+	case "Block":
+		return Block
+	default:
+		return DropOldest
+	}
+}
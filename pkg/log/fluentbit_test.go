@@ -0,0 +1,130 @@
+package log
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestFluentbitHookForwardsEntries(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close() //nolint:errcheck
+
+	received := make(chan string, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			received <- scanner.Text()
+		}
+	}()
+
+	hook := NewFluentbitHook(ln.Addr().String())
+	defer hook.Close()
+
+	entry := &logrus.Entry{Logger: logrus.New(), Message: "hello", Data: logrus.Fields{}, Time: time.Now()}
+	if err := hook.Fire(entry); err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+
+	select {
+	case line := <-received:
+		if line == "" {
+			t.Error("expected a non-empty forwarded line")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for Fluentbit to receive the entry")
+	}
+
+	stats := hook.Stats()
+	if stats.Flushed == 0 {
+		t.Errorf("expected Flushed to be non-zero, got %+v", stats)
+	}
+}
+
+func TestFluentbitHookOverflowDropsUnderDropNewest(t *testing.T) {
+	os.Setenv(LoggingFluentbitQueueCapacityEnv, "1")           //nolint:errcheck
+	os.Setenv(LoggingFluentbitOverflowPolicyEnv, "DropNewest") //nolint:errcheck
+	defer os.Unsetenv(LoggingFluentbitQueueCapacityEnv)        //nolint:errcheck
+	defer os.Unsetenv(LoggingFluentbitOverflowPolicyEnv)       //nolint:errcheck
+
+	hook := NewFluentbitHook("127.0.0.1:1") // unroutable port: sender stays blocked on connect
+	defer hook.Close()
+
+	entry := &logrus.Entry{Logger: logrus.New(), Message: "hello", Data: logrus.Fields{}, Time: time.Now()}
+
+	for i := 0; i < 10; i++ {
+		if err := hook.Fire(entry); err != nil {
+			t.Fatalf("Fire: %v", err)
+		}
+	}
+
+	stats := hook.Stats()
+	if stats.Dropped == 0 {
+		t.Errorf("expected some entries to be dropped under a full queue, got %+v", stats)
+	}
+}
+
+func TestFluentbitHookFlushWaitsForDrain(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close() //nolint:errcheck
+
+	received := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					received <- scanner.Text()
+				}
+			}()
+		}
+	}()
+
+	hook := NewFluentbitHook(ln.Addr().String())
+	defer hook.Close()
+
+	entry := &logrus.Entry{Logger: logrus.New(), Message: "hello", Data: logrus.Fields{}, Time: time.Now()}
+	if err := hook.Fire(entry); err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := hook.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if depth := hook.Stats().QueueDepth; depth != 0 {
+		t.Errorf("expected queue to be drained after Flush, depth=%d", depth)
+	}
+
+	// Flush must not return until sendBatch has actually handed the
+	// entry to the remote, not just until it left the queue, so the
+	// line must already be waiting here with no further delay.
+	select {
+	case line := <-received:
+		if line == "" {
+			t.Error("expected a non-empty forwarded line")
+		}
+	default:
+		t.Fatal("expected the entry to have reached the remote by the time Flush returned")
+	}
+}
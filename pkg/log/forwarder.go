@@ -0,0 +1,237 @@
+package log
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy describes what an asyncForwarder does with entries when
+// its queue is full, selected via the sink-specific overflow-policy env
+// var (e.g. LoggingFluentbitOverflowPolicyEnv).
+type OverflowPolicy uint8
+
+const (
+	// DropOldest evicts the head of the queue to make room for the new entry.
+	DropOldest OverflowPolicy = iota
+	// DropNewest discards the entry that didn't fit.
+	DropNewest
+	// Block waits for room, applying backpressure to the logging caller.
+	Block
+)
+
+const (
+	defaultAsyncQueueCapacity  = 8192
+	defaultAsyncBatchSize      = 100
+	defaultAsyncFlushInterval  = 200 * time.Millisecond
+	defaultAsyncInitialBackoff = time.Second
+	defaultAsyncMaxBackoff     = 30 * time.Second
+)
+
+// Stats is a point-in-time snapshot of an asyncForwarder's counters,
+// surfaced by both FluentbitHook and OTLPHook so higher layers can expose
+// them as Prometheus metrics.
+type Stats struct {
+	Dropped    uint64
+	Retried    uint64
+	Flushed    uint64
+	QueueDepth int
+}
+
+// asyncForwarder owns a bounded queue of records of type T and a
+// background goroutine that batches them by count and by flush interval,
+// handing completed batches to sendBatch with exponential-backoff retry.
+// It backs both FluentbitHook (T = []byte) and OTLPHook (T =
+// otellog.Record) so the two remote sinks share one transport pipeline.
+type asyncForwarder[T any] struct {
+	sendBatch     func(batch []T) error
+	closeBatch    func()
+	overflow      OverflowPolicy
+	queue         chan T
+	batchSize     int
+	flushInterval time.Duration
+
+	dropped uint64
+	retried uint64
+	flushed uint64
+
+	// pending counts entries that have been enqueued but not yet handed
+	// back from sendBatch, whether still sitting in queue or already
+	// dequeued into the run() goroutine's in-memory batch. Flush waits on
+	// this instead of queue length so it can't return while a batch is
+	// in flight.
+	pending int64
+
+	wg   sync.WaitGroup
+	done chan struct{}
+}
+
+// asyncForwarderConfig tunes an asyncForwarder. Zero-valued fields fall
+// back to the package defaults.
+type asyncForwarderConfig[T any] struct {
+	capacity      int
+	overflow      OverflowPolicy
+	batchSize     int
+	flushInterval time.Duration
+	// sendBatch delivers a batch to the remote collector, returning an
+	// error if (and only if) it should be retried with backoff.
+	sendBatch func(batch []T) error
+	// closeBatch releases any resources sendBatch holds (e.g. a
+	// connection). It runs once, from the forwarder's goroutine, after
+	// the final flush on shutdown.
+	closeBatch func()
+}
+
+func newAsyncForwarder[T any](cfg asyncForwarderConfig[T]) *asyncForwarder[T] {
+	capacity := cfg.capacity
+	if capacity <= 0 {
+		capacity = defaultAsyncQueueCapacity
+	}
+	batchSize := cfg.batchSize
+	if batchSize <= 0 {
+		batchSize = defaultAsyncBatchSize
+	}
+	flushInterval := cfg.flushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultAsyncFlushInterval
+	}
+
+	f := &asyncForwarder[T]{
+		sendBatch:     cfg.sendBatch,
+		closeBatch:    cfg.closeBatch,
+		overflow:      cfg.overflow,
+		queue:         make(chan T, capacity),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+	f.wg.Add(1)
+	go f.run()
+	return f
+}
+
+// Enqueue adds payload to the queue, applying the configured
+// OverflowPolicy if it is full. It never blocks except under Block.
+func (f *asyncForwarder[T]) Enqueue(payload T) {
+	select {
+	case f.queue <- payload:
+		atomic.AddInt64(&f.pending, 1)
+		return
+	default:
+	}
+
+	switch f.overflow {
+	case Block:
+		select {
+		case f.queue <- payload:
+			atomic.AddInt64(&f.pending, 1)
+		case <-f.done:
+		}
+	case DropNewest:
+		atomic.AddUint64(&f.dropped, 1)
+	case DropOldest:
+		select {
+		case <-f.queue:
+			atomic.AddUint64(&f.dropped, 1)
+			atomic.AddInt64(&f.pending, -1)
+		default:
+		}
+		select {
+		case f.queue <- payload:
+			atomic.AddInt64(&f.pending, 1)
+		default:
+			atomic.AddUint64(&f.dropped, 1)
+		}
+	}
+}
+
+// Flush blocks until every enqueued entry has either been delivered by
+// sendBatch or given up on, or ctx is done, so callers can wait for
+// in-flight entries (including ones already dequeued into the run()
+// goroutine's in-memory batch) to ship before the process exits.
+func (f *asyncForwarder[T]) Flush(ctx context.Context) error {
+	for atomic.LoadInt64(&f.pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+// Close stops the background sender without draining the queue; call
+// Flush first if pending entries must still be shipped.
+func (f *asyncForwarder[T]) Close() {
+	close(f.done)
+	f.wg.Wait()
+}
+
+// Stats returns a snapshot of the forwarder's internal counters.
+func (f *asyncForwarder[T]) Stats() Stats {
+	return Stats{
+		Dropped:    atomic.LoadUint64(&f.dropped),
+		Retried:    atomic.LoadUint64(&f.retried),
+		Flushed:    atomic.LoadUint64(&f.flushed),
+		QueueDepth: len(f.queue),
+	}
+}
+
+func (f *asyncForwarder[T]) run() {
+	defer f.wg.Done()
+	if f.closeBatch != nil {
+		defer f.closeBatch()
+	}
+
+	batch := make([]T, 0, f.batchSize)
+	ticker := time.NewTicker(f.flushInterval)
+	defer ticker.Stop()
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := f.sendWithRetry(batch); err == nil {
+			atomic.AddUint64(&f.flushed, uint64(len(batch)))
+		}
+		atomic.AddInt64(&f.pending, -int64(len(batch)))
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case p := <-f.queue:
+			batch = append(batch, p)
+			if len(batch) >= f.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-f.done:
+			flush()
+			return
+		}
+	}
+}
+
+// sendWithRetry calls sendBatch, retrying with exponential backoff until
+// it succeeds or the forwarder is closed.
+func (f *asyncForwarder[T]) sendWithRetry(batch []T) error {
+	backoff := defaultAsyncInitialBackoff
+	for {
+		err := f.sendBatch(batch)
+		if err == nil {
+			return nil
+		}
+		atomic.AddUint64(&f.retried, 1)
+		select {
+		case <-time.After(backoff):
+		case <-f.done:
+			return err
+		}
+		if backoff *= 2; backoff > defaultAsyncMaxBackoff {
+			backoff = defaultAsyncMaxBackoff
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAsyncForwarderBlockPolicyAppliesBackpressure exercises the Block
+// overflow policy directly against asyncForwarder, since it's easier to
+// control a stalled sendBatch this way than over a real network
+// connection (see fluentbit_test.go for the DropNewest/DropOldest cases).
+func TestAsyncForwarderBlockPolicyAppliesBackpressure(t *testing.T) {
+	block := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	f := newAsyncForwarder(asyncForwarderConfig[int]{
+		capacity:      1,
+		batchSize:     1,
+		flushInterval: time.Hour,
+		overflow:      Block,
+		sendBatch: func(batch []int) error {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-block
+			return nil
+		},
+	})
+	defer f.Close()
+
+	f.Enqueue(1) // picked up by run() and handed to the stalled sendBatch
+	<-started
+
+	f.Enqueue(2) // fills the now-empty queue (capacity 1)
+
+	done := make(chan struct{})
+	go func() {
+		f.Enqueue(3) // queue is full and nothing is draining it
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("expected Enqueue to block while the queue is full under the Block policy")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(block) // let the stalled sendBatch return, freeing the consumer to drain the queue
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the blocked Enqueue to unblock once room freed up")
+	}
+}
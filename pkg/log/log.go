@@ -11,6 +11,7 @@ import (
 	"github.com/luci/go-render/render"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/kanisterio/kanister/pkg/field"
 )
@@ -30,27 +31,40 @@ const (
 // OutputSink describes the current output sink.
 type OutputSink uint8
 
-// Valid log sinks: stderr or fluentbit
+// Valid log sinks: stderr, fluentbit, a rotatable file, or OTLP
 const (
 	StderrSink OutputSink = iota
 	FluentbitSink
+	FileSink
+	OTLPSink
 )
 
 // Names of environment variables to configure the logging sink
 const (
-	LoggingServiceHostEnv = "LOGGING_SVC_SERVICE_HOST"
-	LoggingServicePortEnv = "LOGGING_SVC_SERVICE_PORT_LOGGING"
+	LoggingServiceHostEnv  = "LOGGING_SVC_SERVICE_HOST"
+	LoggingServicePortEnv  = "LOGGING_SVC_SERVICE_PORT_LOGGING"
+	LoggingOTLPEndpointEnv = "LOGGING_OTLP_ENDPOINT"
 )
 
+// Sampling (WithSampler/WithSampleKey) extends the package's existing
+// Logger interface; see sampler.go.
+
 type logger struct {
-	level Level
-	ctx   context.Context
-	err   error
+	level     Level
+	ctx       context.Context
+	err       error
+	sampler   Sampler
+	sampleKey string
 }
 
 // common logger implementation used in the library
 var log = logrus.New()
 
+// activeFluentbitHook is the hook installed by the most recent
+// SetOutput(FluentbitSink) call, if any, so FlushFluentbit and
+// FluentbitStats can reach it without callers threading it through.
+var activeFluentbitHook *FluentbitHook
+
 // SetOutput sets the output destination.
 func SetOutput(sink OutputSink) error {
 	switch sink {
@@ -68,6 +82,30 @@ func SetOutput(sink OutputSink) error {
 		}
 		hook := NewFluentbitHook(fbitAddr + ":" + fbitPort)
 		log.AddHook(hook)
+		activeFluentbitHook = hook
+		return nil
+	case FileSink:
+		path, ok := os.LookupEnv(LoggingFilePathEnv)
+		if !ok {
+			return errors.New("Unable to find log file path")
+		}
+		f, err := newReopenableFile(path, fileSinkMaxBytes())
+		if err != nil {
+			return err
+		}
+		log.SetOutput(f)
+		return nil
+	case OTLPSink:
+		endpoint, ok := os.LookupEnv(LoggingOTLPEndpointEnv)
+		if !ok {
+			return errors.New("Unable to find OTLP endpoint")
+		}
+		hook, err := NewOTLPHook(endpoint)
+		if err != nil {
+			return err
+		}
+		log.AddHook(hook)
+		activeOTLPHook = hook
 		return nil
 	default:
 		return errors.New("not implemented")
@@ -89,6 +127,26 @@ type renderFormatter struct {
 	formatter logrus.Formatter
 }
 
+// redactingFormatter redacts entry.Data before delegating to formatter,
+// without otherwise touching field types. renderFormatter also stringifies
+// non-string/error fields via render.Render, which is only appropriate for
+// the human-readable text output; JSON output must keep native types (an
+// int field like "status" has to stay a JSON number, not become "200") so
+// it redacts directly instead, the same way entryToJSON and the OTLP path do.
+type redactingFormatter struct {
+	formatter logrus.Formatter
+}
+
+func (f *redactingFormatter) Format(e *logrus.Entry) ([]byte, error) {
+	if e != nil && len(e.Data) > 0 {
+		cp := *e
+		cp.Buffer = nil
+		cp.Data = redactFields(copyFields(e.Data))
+		return f.formatter.Format(&cp)
+	}
+	return f.formatter.Format(e)
+}
+
 // SetFormatter sets the output formatter.
 func SetFormatter(format OutputFormat) {
 	switch format {
@@ -98,7 +156,8 @@ func SetFormatter(format OutputFormat) {
 				FullTimestamp:   true,
 				TimestampFormat: time.RFC3339Nano}})
 	case JSONFormat:
-		log.SetFormatter(&logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano})
+		log.SetFormatter(&redactingFormatter{
+			&logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano}})
 	default:
 		panic("not implemented")
 	}
@@ -123,8 +182,11 @@ func (f *renderFormatter) Format(e *logrus.Entry) ([]byte, error) {
 		cp.Buffer = nil
 		data := make(logrus.Fields, len(e.Data))
 
+		// Redact before rendering so render.Render never sees raw secrets.
+		redacted := redactFields(copyFields(e.Data))
+
 		// Expand / render the fields in the entry
-		for k, v := range e.Data {
+		for k, v := range redacted {
 			switch t := v.(type) {
 			case error:
 				errmsg, stacktrace := formatError(t)
@@ -151,6 +213,44 @@ func init() {
 	SetFormatter(TextFormat)
 }
 
+// FlushFluentbit drains the Fluentbit forwarder's queue, if one is
+// installed, so callers can wait for in-flight entries to ship before the
+// process exits. It is a no-op if SetOutput(FluentbitSink) was never
+// called.
+func FlushFluentbit(ctx context.Context) error {
+	if activeFluentbitHook == nil {
+		return nil
+	}
+	return activeFluentbitHook.Flush(ctx)
+}
+
+// FluentbitStats returns the installed Fluentbit forwarder's counters, or
+// the zero value if SetOutput(FluentbitSink) was never called.
+func FluentbitStats() Stats {
+	if activeFluentbitHook == nil {
+		return Stats{}
+	}
+	return activeFluentbitHook.Stats()
+}
+
+// FlushOTLP drains the OTLP forwarder's queue, if one is installed. It is
+// a no-op if SetOutput(OTLPSink) was never called.
+func FlushOTLP(ctx context.Context) error {
+	if activeOTLPHook == nil {
+		return nil
+	}
+	return activeOTLPHook.Flush(ctx)
+}
+
+// OTLPStats returns the installed OTLP forwarder's counters, or the zero
+// value if SetOutput(OTLPSink) was never called.
+func OTLPStats() Stats {
+	if activeOTLPHook == nil {
+		return Stats{}
+	}
+	return activeOTLPHook.Stats()
+}
+
 func Info() Logger {
 	return &logger{
 		level: InfoLevel,
@@ -183,6 +283,19 @@ func WithError(err error) Logger {
 }
 
 func (l *logger) Print(msg string, fields ...field.M) {
+	if sampler := l.sampler; sampler != nil || defaultSampler != nil {
+		if sampler == nil {
+			sampler = defaultSampler
+		}
+		key := l.sampleKey
+		if key == "" {
+			key = msg
+		}
+		if !sampler.Sample(l.level, key) {
+			return
+		}
+	}
+
 	logFields := make(logrus.Fields)
 
 	if ctxFields := field.FromContext(l.ctx); ctxFields != nil {
@@ -197,6 +310,13 @@ func (l *logger) Print(msg string, fields ...field.M) {
 		}
 	}
 
+	if l.ctx != nil {
+		if sc := trace.SpanContextFromContext(l.ctx); sc.IsValid() {
+			logFields["trace_id"] = sc.TraceID().String()
+			logFields["span_id"] = sc.SpanID().String()
+		}
+	}
+
 	entry := log.WithFields(logFields)
 	if l.err != nil {
 		entry = entry.WithError(l.err)
@@ -214,6 +334,20 @@ func (l *logger) WithError(err error) Logger {
 	return l
 }
 
+// WithSampler attaches s to this logger, overriding the process-wide
+// default sampler (see SetSampler) for every subsequent Print call.
+func (l *logger) WithSampler(s Sampler) Logger {
+	l.sampler = s
+	return l
+}
+
+// WithSampleKey sets the key passed to the sampler's Sample method,
+// overriding the default of using the log message itself as the key.
+func (l *logger) WithSampleKey(key string) Logger {
+	l.sampleKey = key
+	return l
+}
+
 // Scrapes fields of interest from the logrus.Entry and converts then into a JSON []byte.
 func entryToJSON(entry *logrus.Entry) []byte {
 	data := make(logrus.Fields, len(entry.Data)+3)
@@ -225,6 +359,7 @@ func entryToJSON(entry *logrus.Entry) []byte {
 	for k, v := range entry.Data {
 		data[k] = v
 	}
+	data = redactFields(data)
 
 	bytes, err := json.Marshal(data)
 	if err != nil {
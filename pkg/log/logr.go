@@ -0,0 +1,86 @@
+package log
+
+import (
+	"github.com/go-logr/logr"
+
+	"github.com/kanisterio/kanister/pkg/field"
+)
+
+// logrSink adapts this package to logr.LogSink so k8s.io/klog/v2 and any
+// other contextual-logging consumer can share kanister's formatter and
+// output sinks instead of logging through klog's own writer.
+type logrSink struct {
+	name   string
+	values field.M
+}
+
+// NewLogrSink returns a logr.LogSink backed by this package's logger.
+func NewLogrSink() logr.LogSink {
+	return &logrSink{values: field.M{}}
+}
+
+func (s *logrSink) Init(logr.RuntimeInfo) {}
+
+// Enabled reports all levels as enabled; verbosity filtering beyond the
+// DebugLevel/InfoLevel split made by Info is left to the sinks/formatter
+// configured via SetOutput and SetFormatter.
+func (s *logrSink) Enabled(int) bool {
+	return true
+}
+
+// Info maps logr's verbosity level onto our levels: level 0 is the
+// default "info" verbosity, anything higher is treated as debug-level
+// detail so it can be filtered the same way as our other Debug calls.
+func (s *logrSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	logger := Info()
+	if level > 0 {
+		logger = Debug()
+	}
+	logger.Print(s.withName(msg), s.fields(keysAndValues...))
+}
+
+func (s *logrSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	Error().WithError(err).Print(s.withName(msg), s.fields(keysAndValues...))
+}
+
+func (s *logrSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	cp := *s
+	cp.values = s.fields(keysAndValues...)
+	return &cp
+}
+
+func (s *logrSink) WithName(name string) logr.LogSink {
+	cp := *s
+	if s.name != "" {
+		cp.name = s.name + "." + name
+	} else {
+		cp.name = name
+	}
+	return &cp
+}
+
+func (s *logrSink) withName(msg string) string {
+	if s.name == "" {
+		return msg
+	}
+	return s.name + ": " + msg
+}
+
+// fields merges the sink's accumulated WithValues pairs with the
+// key/value pairs passed to this call.
+func (s *logrSink) fields(keysAndValues ...interface{}) field.M {
+	fields := make(field.M, len(s.values)+len(keysAndValues)/2)
+	for k, v := range s.values {
+		fields[k] = v
+	}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			continue
+		}
+		fields[key] = keysAndValues[i+1]
+	}
+	return fields
+}
+
+var _ logr.LogSink = (*logrSink)(nil)
@@ -0,0 +1,85 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+func TestLogrSinkWithNameAndValues(t *testing.T) {
+	SetFormatter(JSONFormat)
+	defer SetFormatter(TextFormat)
+	old := log.Out
+	defer log.SetOutput(old)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+
+	l := logr.New(NewLogrSink()).WithName("controller").WithValues("worker", 3)
+	l.Info("reconciled", "status", 200)
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["msg"] != "controller: reconciled" {
+		t.Errorf("expected name-prefixed message, got %v", out["msg"])
+	}
+	if out["worker"] != float64(3) {
+		t.Errorf("expected carried-over WithValues field, got %v", out["worker"])
+	}
+	if out["status"] != float64(200) {
+		t.Errorf("expected status field, got %v", out["status"])
+	}
+}
+
+func TestLogrSinkInfoMapsVerbosityToDebug(t *testing.T) {
+	SetFormatter(JSONFormat)
+	defer SetFormatter(TextFormat)
+	old := log.Out
+	defer log.SetOutput(old)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+
+	logr.New(NewLogrSink()).V(1).Info("reconciled")
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["level"] != "debug" {
+		t.Errorf("expected V(1) to surface at debug level, got %v", out["level"])
+	}
+}
+
+func TestLogrSinkError(t *testing.T) {
+	SetFormatter(JSONFormat)
+	defer SetFormatter(TextFormat)
+	old := log.Out
+	defer log.SetOutput(old)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+
+	logr.New(NewLogrSink()).Error(errors.New("boom"), "failed")
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["error"] != "boom" {
+		t.Errorf("expected error field, got %v", out["error"])
+	}
+}
+
+func TestLogrSinkOddKeysAndValuesIgnoresDanglingKey(t *testing.T) {
+	s := &logrSink{}
+	fields := s.fields("a", 1, "dangling")
+	if len(fields) != 1 || fields["a"] != 1 {
+		t.Errorf("expected only the complete pair to survive, got %v", fields)
+	}
+}
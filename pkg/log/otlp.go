@@ -0,0 +1,148 @@
+package log
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// activeOTLPHook is the hook installed by the most recent
+// SetOutput(OTLPSink) call, if any.
+var activeOTLPHook *OTLPHook
+
+// OTel Logs data model severity numbers for our three levels.
+// https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-severitynumber
+const (
+	otlpSeverityDebug = 5
+	otlpSeverityInfo  = 9
+	otlpSeverityError = 17
+)
+
+// OTLPHook is a logrus.Hook that ships log records to an OTLP/gRPC
+// collector. It reuses the same asyncForwarder as FluentbitHook so both
+// remote sinks share one transport pipeline; only the batch sender and
+// the record shape differ.
+type OTLPHook struct {
+	fw       *asyncForwarder[sdklog.Record]
+	exporter *otlploggrpc.Exporter
+	resource *resource.Resource
+}
+
+// NewOTLPHook dials endpoint and starts the background sender. The
+// resource attached to every batch is populated from OTEL_SERVICE_NAME
+// and OTEL_RESOURCE_ATTRIBUTES per the OTel SDK's environment
+// conventions (resource.WithFromEnv), so records are properly attributed
+// in the collector.
+func NewOTLPHook(endpoint string) (*OTLPHook, error) {
+	ctx := context.Background()
+
+	exporter, err := otlploggrpc.New(ctx, otlploggrpc.WithEndpoint(endpoint), otlploggrpc.WithInsecure())
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to create OTLP log exporter")
+	}
+	res, err := resource.New(ctx, resource.WithFromEnv())
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to build OTLP resource")
+	}
+
+	h := &OTLPHook{exporter: exporter, resource: res}
+	h.fw = newAsyncForwarder(asyncForwarderConfig[sdklog.Record]{
+		overflow:   overflowPolicyFromEnv(),
+		sendBatch:  h.sendBatch,
+		closeBatch: h.closeExporter,
+	})
+	return h, nil
+}
+
+// Levels implements logrus.Hook.
+func (h *OTLPHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook, enqueueing a record built from entry for
+// the background sender to export.
+func (h *OTLPHook) Fire(entry *logrus.Entry) error {
+	rec := entryToOTLPRecord(entry)
+	rec.SetResource(h.resource)
+	h.fw.Enqueue(rec)
+	return nil
+}
+
+// Flush blocks until the queue has drained or ctx is done.
+func (h *OTLPHook) Flush(ctx context.Context) error {
+	return h.fw.Flush(ctx)
+}
+
+// Close stops the background sender and shuts down the exporter.
+func (h *OTLPHook) Close() {
+	h.fw.Close()
+}
+
+// Stats returns a snapshot of the hook's internal counters.
+func (h *OTLPHook) Stats() Stats {
+	return h.fw.Stats()
+}
+
+func (h *OTLPHook) sendBatch(batch []sdklog.Record) error {
+	return h.exporter.Export(context.Background(), batch)
+}
+
+func (h *OTLPHook) closeExporter() {
+	_ = h.exporter.Shutdown(context.Background()) //nolint:errcheck
+}
+
+// entryToOTLPRecord renders a logrus.Entry into the OTLP log record
+// shape, mapping our Level to an OTel severity number and turning
+// field.M entries (already flattened into entry.Data) into attributes.
+// entry.Data is redacted first so secrets never reach the collector. The
+// returned record has no resource attached; callers set one via
+// SetResource before handing it to the exporter.
+func entryToOTLPRecord(entry *logrus.Entry) sdklog.Record {
+	rec := sdklog.Record{}
+	rec.SetTimestamp(entry.Time)
+	rec.SetBody(otellog.StringValue(entry.Message))
+	rec.SetSeverity(otellog.Severity(otlpSeverityFromLevel(entry.Level)))
+
+	for k, v := range redactFields(copyFields(entry.Data)) {
+		rec.AddAttributes(otellog.KeyValue{Key: k, Value: otlpAttributeValue(v)})
+	}
+	return rec
+}
+
+func otlpSeverityFromLevel(l logrus.Level) int {
+	switch Level(l) {
+	case DebugLevel:
+		return otlpSeverityDebug
+	case ErrorLevel:
+		return otlpSeverityError
+	default:
+		return otlpSeverityInfo
+	}
+}
+
+func otlpAttributeValue(v interface{}) otellog.Value {
+	switch t := v.(type) {
+	case string:
+		return otellog.StringValue(t)
+	case int:
+		return otellog.Int64Value(int64(t))
+	case int64:
+		return otellog.Int64Value(t)
+	case float64:
+		return otellog.Float64Value(t)
+	case bool:
+		return otellog.BoolValue(t)
+	case error:
+		return otellog.StringValue(t.Error())
+	case fmt.Stringer:
+		return otellog.StringValue(t.String())
+	default:
+		return otellog.StringValue(fmt.Sprintf("%+v", t))
+	}
+}
@@ -0,0 +1,101 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestOtlpSeverityFromLevel(t *testing.T) {
+	cases := map[logrus.Level]int{
+		logrus.Level(DebugLevel): otlpSeverityDebug,
+		logrus.Level(InfoLevel):  otlpSeverityInfo,
+		logrus.Level(ErrorLevel): otlpSeverityError,
+	}
+	for level, want := range cases {
+		if got := otlpSeverityFromLevel(level); got != want {
+			t.Errorf("otlpSeverityFromLevel(%v) = %d, want %d", level, got, want)
+		}
+	}
+}
+
+func TestEntryToOTLPRecord(t *testing.T) {
+	entry := &logrus.Entry{
+		Logger:  logrus.New(),
+		Message: "handled",
+		Level:   logrus.Level(ErrorLevel),
+		Time:    time.Now(),
+		Data: logrus.Fields{
+			"status":   200,
+			"trace_id": "abc123",
+			"err":      errors.New("boom"),
+		},
+	}
+
+	rec := entryToOTLPRecord(entry)
+
+	if rec.Body().AsString() != "handled" {
+		t.Errorf("expected body %q, got %q", "handled", rec.Body().AsString())
+	}
+	if int(rec.Severity()) != otlpSeverityError {
+		t.Errorf("expected severity %d, got %d", otlpSeverityError, int(rec.Severity()))
+	}
+
+	if rec.AttributesLen() != len(entry.Data) {
+		t.Errorf("expected %d attributes, got %d", len(entry.Data), rec.AttributesLen())
+	}
+}
+
+func TestEntryToOTLPRecordAcceptsResource(t *testing.T) {
+	entry := &logrus.Entry{
+		Logger:  logrus.New(),
+		Message: "handled",
+		Level:   logrus.Level(InfoLevel),
+		Time:    time.Now(),
+		Data:    logrus.Fields{},
+	}
+
+	res, err := resource.New(context.Background(), resource.WithAttributes(attribute.String("service.name", "test-service")))
+	if err != nil {
+		t.Fatalf("resource.New: %v", err)
+	}
+
+	rec := entryToOTLPRecord(entry)
+	rec.SetResource(res)
+
+	if rec.Resource() != res {
+		t.Error("expected the record to carry the attached resource")
+	}
+}
+
+func TestEntryToOTLPRecordRedactsSecrets(t *testing.T) {
+	entry := &logrus.Entry{
+		Logger:  logrus.New(),
+		Message: "login",
+		Level:   logrus.Level(InfoLevel),
+		Time:    time.Now(),
+		Data: logrus.Fields{
+			"password": "hunter2",
+		},
+	}
+
+	rec := entryToOTLPRecord(entry)
+
+	var redacted bool
+	rec.WalkAttributes(func(kv otellog.KeyValue) bool {
+		if kv.Key == "password" && kv.Value.AsString() == redactedPlaceholder {
+			redacted = true
+		}
+		return true
+	})
+	if !redacted {
+		t.Error("expected password attribute to be redacted before reaching the OTLP record")
+	}
+}
@@ -0,0 +1,133 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/kanisterio/kanister/pkg/field"
+)
+
+// redactedPlaceholder replaces any value a Redactor decides to redact.
+const redactedPlaceholder = "***"
+
+// Redactor inspects a field's key and value and returns the value that
+// should actually reach a sink. Returning value unchanged means "no
+// redaction needed".
+type Redactor interface {
+	Redact(key string, value interface{}) interface{}
+}
+
+// RedactorFunc adapts a plain function to the Redactor interface.
+type RedactorFunc func(key string, value interface{}) interface{}
+
+// Redact implements Redactor.
+func (f RedactorFunc) Redact(key string, value interface{}) interface{} {
+	return f(key, value)
+}
+
+var (
+	redactorsMu sync.RWMutex
+	redactors   = map[string]Redactor{}
+)
+
+func init() {
+	RegisterRedactor("aws-access-key", RedactorFunc(redactAWSAccessKey))
+	RegisterRedactor("bearer-token", RedactorFunc(redactBearerToken))
+	RegisterRedactor("pem-block", RedactorFunc(redactPEMBlock))
+	RegisterRedactor("sensitive-field-name", RedactorFunc(redactByFieldName))
+}
+
+// RegisterRedactor installs r under name, replacing any redactor
+// previously registered under the same name. Every registered redactor
+// runs over every field before it reaches a sink's formatter.
+func RegisterRedactor(name string, r Redactor) {
+	redactorsMu.Lock()
+	defer redactorsMu.Unlock()
+	redactors[name] = r
+}
+
+// UnregisterRedactor removes the redactor installed under name, if any.
+func UnregisterRedactor(name string) {
+	redactorsMu.Lock()
+	defer redactorsMu.Unlock()
+	delete(redactors, name)
+}
+
+// copyFields returns a shallow copy of in so redaction never mutates a
+// map the caller still holds a reference to.
+func copyFields(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// redactFields runs every registered redactor over data in place,
+// first replacing any field.Sensitive value unconditionally.
+func redactFields(data map[string]interface{}) map[string]interface{} {
+	redactorsMu.RLock()
+	defer redactorsMu.RUnlock()
+
+	for k, v := range data {
+		if _, ok := field.Unwrap(v); ok {
+			data[k] = redactedPlaceholder
+			continue
+		}
+		for _, r := range redactors {
+			v = r.Redact(k, v)
+		}
+		data[k] = v
+	}
+	return data
+}
+
+var (
+	awsAccessKeyPattern = regexp.MustCompile(`AKIA[0-9A-Z]{16}`)
+	bearerTokenPattern  = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`)
+	pemBlockPattern     = regexp.MustCompile(`(?s)-----BEGIN [^-]+-----.*?-----END [^-]+-----`)
+)
+
+// sensitiveFieldNameSubstrings are lower-cased substrings that mark a
+// field name as sensitive regardless of what surrounds them, so
+// "access_token", "client_secret", "db_password", and "apiToken" are all
+// caught the same as the bare words they contain.
+var sensitiveFieldNameSubstrings = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"api_key",
+}
+
+func redactAWSAccessKey(_ string, v interface{}) interface{} {
+	return redactPattern(v, awsAccessKeyPattern)
+}
+
+func redactBearerToken(_ string, v interface{}) interface{} {
+	return redactPattern(v, bearerTokenPattern)
+}
+
+func redactPEMBlock(_ string, v interface{}) interface{} {
+	return redactPattern(v, pemBlockPattern)
+}
+
+func redactPattern(v interface{}, pattern *regexp.Regexp) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	return pattern.ReplaceAllString(s, redactedPlaceholder)
+}
+
+func redactByFieldName(key string, v interface{}) interface{} {
+	lower := strings.ToLower(key)
+	for _, substr := range sensitiveFieldNameSubstrings {
+		if strings.Contains(lower, substr) {
+			return redactedPlaceholder
+		}
+	}
+	return v
+}
@@ -0,0 +1,18 @@
+package log
+
+import "testing"
+
+// BenchmarkRedactFieldsNoMatches measures the redaction pipeline's
+// overhead on an entry where none of the built-in redactors match,
+// which is the common case and must stay cheap.
+func BenchmarkRedactFieldsNoMatches(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data := copyFields(map[string]interface{}{
+			"request_id": "abc-123",
+			"status":     200,
+			"message":    "reconciled backup successfully",
+		})
+		redactFields(data)
+	}
+}
@@ -0,0 +1,19 @@
+package log
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+var testRedactorSeq uint64
+
+// WithRedactor installs r as a redactor for the duration of t, removing
+// it automatically on cleanup. It's exported (rather than living in a
+// _test.go file) so other packages' tests can use it too.
+func WithRedactor(t testing.TB, r Redactor) {
+	t.Helper()
+	name := fmt.Sprintf("test-redactor-%d", atomic.AddUint64(&testRedactorSeq, 1))
+	RegisterRedactor(name, r)
+	t.Cleanup(func() { UnregisterRedactor(name) })
+}
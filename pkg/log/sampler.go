@@ -0,0 +1,189 @@
+package log
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kanisterio/kanister/pkg/field"
+)
+
+// Names of environment variables used to install a default Sampler at
+// init time.
+const (
+	LogSampleRateEnv  = "LOG_SAMPLE_RATE"
+	LogSampleBurstEnv = "LOG_SAMPLE_BURST"
+)
+
+const sampleDropFlushInterval = 30 * time.Second
+
+// Sampler decides whether a log line for key at level should be emitted.
+// Implementations must be safe for concurrent use. Install one process
+// wide with SetSampler, or attach one to a single Logger with
+// WithSampler.
+type Sampler interface {
+	Sample(level Level, key string) bool
+}
+
+// defaultSampler is consulted by Print when a logger has no sampler of
+// its own. nil means "sample everything".
+var defaultSampler Sampler
+
+// SetSampler installs s as the process-wide default sampler, used by any
+// Logger that hasn't called WithSampler itself.
+func SetSampler(s Sampler) {
+	defaultSampler = s
+}
+
+func init() {
+	if s := samplerFromEnv(); s != nil {
+		SetSampler(s)
+	}
+}
+
+func samplerFromEnv() Sampler {
+	rate, ok := os.LookupEnv(LogSampleRateEnv)
+	if !ok {
+		return nil
+	}
+	perSecond, err := strconv.ParseFloat(rate, 64)
+	if err != nil || perSecond <= 0 {
+		return nil
+	}
+	burst := 1
+	if b, ok := os.LookupEnv(LogSampleBurstEnv); ok {
+		if n, err := strconv.Atoi(b); err == nil && n > 0 {
+			burst = n
+		}
+	}
+	return NewRateLimiter(perSecond, burst)
+}
+
+// rateLimiter is a token-bucket Sampler shared across all keys: it
+// limits the overall rate of log lines regardless of which key they
+// carry.
+type rateLimiter struct {
+	mu        sync.Mutex
+	tokens    float64
+	perSecond float64
+	burst     float64
+	last      time.Time
+}
+
+// NewRateLimiter returns a Sampler that allows at most perSecond log
+// lines per second on average, with bursts of up to burst lines.
+func NewRateLimiter(perSecond float64, burst int) Sampler {
+	return &rateLimiter{
+		tokens:    float64(burst),
+		perSecond: perSecond,
+		burst:     float64(burst),
+		last:      time.Now(),
+	}
+}
+
+func (r *rateLimiter) Sample(_ Level, key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.tokens += now.Sub(r.last).Seconds() * r.perSecond
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+	r.last = now
+
+	if r.tokens < 1 {
+		recordSampleDrop(key)
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// countSampler is a "first N then every Mth" Sampler in the style of
+// zap's sampling core: the first `first` log lines for a given key
+// always go through, and thereafter only every `thereafter`th does.
+type countSampler struct {
+	first      uint64
+	thereafter uint64
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+// NewCountSampler returns a Sampler that lets the first `first` log
+// lines per key through, then one in every `thereafter` after that. A
+// non-positive thereafter drops everything past the first N.
+func NewCountSampler(first, thereafter int) Sampler {
+	return &countSampler{
+		first:      uint64(first),
+		thereafter: uint64(thereafter),
+		counts:     make(map[string]uint64),
+	}
+}
+
+func (s *countSampler) Sample(_ Level, key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[key]++
+	n := s.counts[key]
+
+	if n <= s.first {
+		return true
+	}
+	if s.thereafter == 0 || (n-s.first)%s.thereafter != 0 {
+		recordSampleDrop(key)
+		return false
+	}
+	return true
+}
+
+// sampleDrops tracks per-key dropped counts so they can be summarized
+// periodically instead of silently vanishing.
+var sampleDrops sync.Map // key string -> *uint64
+
+var sampleFlusherOnce sync.Once
+
+func recordSampleDrop(key string) {
+	v, _ := sampleDrops.LoadOrStore(key, new(uint64))
+	atomic.AddUint64(v.(*uint64), 1)
+	sampleFlusherOnce.Do(startSampleDropFlusher)
+}
+
+// startSampleDropFlusher runs for the lifetime of the process once any
+// sampler has dropped at least one entry, periodically logging a summary
+// so operators can see suppression is happening.
+func startSampleDropFlusher() {
+	go func() {
+		ticker := time.NewTicker(sampleDropFlushInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			flushSampleDrops()
+		}
+	}()
+}
+
+// flushSampleDrops emits a single summary log entry covering every key
+// with drops since the last flush, rather than one entry per key.
+func flushSampleDrops() {
+	counts := make(map[string]uint64)
+	var total uint64
+	sampleDrops.Range(func(k, v interface{}) bool {
+		n := atomic.SwapUint64(v.(*uint64), 0)
+		if n > 0 {
+			counts[k.(string)] = n
+			total += n
+		}
+		return true
+	})
+	if total == 0 {
+		return
+	}
+	Info().Print("log sampling suppressed entries", field.M{
+		"log_sampled_dropped": total,
+		"log_sampled_by_key":  counts,
+	})
+}
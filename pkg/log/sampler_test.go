@@ -0,0 +1,119 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCountSamplerAllowsFirstNThenEveryMth(t *testing.T) {
+	s := NewCountSampler(2, 3)
+
+	var allowed int
+	for i := 0; i < 8; i++ {
+		if s.Sample(InfoLevel, "k") {
+			allowed++
+		}
+	}
+	// first 2 (calls 1,2) + every 3rd after that (calls 5, 8) = 4
+	if allowed != 4 {
+		t.Errorf("expected 4 allowed calls, got %d", allowed)
+	}
+}
+
+func TestCountSamplerKeysAreIndependent(t *testing.T) {
+	s := NewCountSampler(1, 0)
+
+	if !s.Sample(InfoLevel, "a") {
+		t.Error("expected first call for key a to be allowed")
+	}
+	if !s.Sample(InfoLevel, "b") {
+		t.Error("expected first call for key b to be allowed")
+	}
+	if s.Sample(InfoLevel, "a") {
+		t.Error("expected second call for key a to be dropped")
+	}
+}
+
+func TestRateLimiterEnforcesBurst(t *testing.T) {
+	rl := NewRateLimiter(0, 2)
+
+	if !rl.Sample(InfoLevel, "k") {
+		t.Error("expected first call within burst to be allowed")
+	}
+	if !rl.Sample(InfoLevel, "k") {
+		t.Error("expected second call within burst to be allowed")
+	}
+	if rl.Sample(InfoLevel, "k") {
+		t.Error("expected third call to exceed the burst and be dropped")
+	}
+}
+
+func TestFlushSampleDropsEmitsOneSummaryForAllKeys(t *testing.T) {
+	recordSampleDrop("alpha")
+	recordSampleDrop("alpha")
+	recordSampleDrop("beta")
+
+	SetFormatter(JSONFormat)
+	defer SetFormatter(TextFormat)
+	old := log.Out
+	defer log.SetOutput(old)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+
+	flushSampleDrops()
+
+	lines := bytes.Count(buf.Bytes(), []byte("\n"))
+	if lines != 1 {
+		t.Errorf("expected a single summary log entry covering both keys, got %d lines: %q", lines, buf.String())
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"log_sampled_dropped":3`)) {
+		t.Errorf("expected the total dropped count across all keys, got %q", buf.String())
+	}
+}
+
+func TestLoggerPrintDroppedBySampler(t *testing.T) {
+	SetFormatter(JSONFormat)
+	defer SetFormatter(TextFormat)
+	old := log.Out
+	defer log.SetOutput(old)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+
+	l := Info().WithSampler(NewCountSampler(0, 0)).WithSampleKey("never")
+	l.Print("should be dropped")
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output when the sampler drops everything, got %q", buf.String())
+	}
+}
+
+func TestLoggerPrintUsesMessageAsDefaultSampleKey(t *testing.T) {
+	s := NewCountSampler(1, 0)
+	l := &logger{level: InfoLevel, sampler: s}
+
+	SetFormatter(JSONFormat)
+	defer SetFormatter(TextFormat)
+	old := log.Out
+	defer log.SetOutput(old)
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+
+	l.Print("alpha")
+	if buf.Len() == 0 {
+		t.Error("expected the first occurrence of a message to be sampled in")
+	}
+
+	buf.Reset()
+	l.Print("alpha")
+	if buf.Len() != 0 {
+		t.Error("expected the second occurrence of the same message to be sampled out")
+	}
+
+	buf.Reset()
+	l.Print("beta")
+	if buf.Len() == 0 {
+		t.Error("expected a different message to use its own counter and be sampled in")
+	}
+}
@@ -0,0 +1,97 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/kanisterio/kanister/pkg/field"
+)
+
+// slogHandler adapts this package's logger and sinks to the standard
+// library's log/slog.Handler interface, so dependencies that have
+// migrated to slog can log through the same renderFormatter and output
+// sinks as the rest of kanister instead of picking their own logger.
+type slogHandler struct {
+	level  Level
+	groups []string
+	attrs  field.M
+}
+
+// NewSlogHandler returns a slog.Handler that routes records through the
+// package's configured formatter and sinks. Records whose level maps to
+// something less severe than level are dropped.
+func NewSlogHandler(level Level) slog.Handler {
+	return &slogHandler{level: level, attrs: field.M{}}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return slogToLevel(level) <= h.level
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := make(field.M, len(h.attrs)+r.NumAttrs())
+	for k, v := range h.attrs {
+		fields[k] = v
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addSlogAttr(fields, h.groups, a)
+		return true
+	})
+
+	l := &logger{level: slogToLevel(r.Level), ctx: ctx}
+	l.Print(r.Message, fields)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	cp := *h
+	cp.attrs = make(field.M, len(h.attrs)+len(attrs))
+	for k, v := range h.attrs {
+		cp.attrs[k] = v
+	}
+	for _, a := range attrs {
+		addSlogAttr(cp.attrs, h.groups, a)
+	}
+	return &cp
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	cp := *h
+	cp.groups = append(append([]string{}, h.groups...), name)
+	return &cp
+}
+
+// addSlogAttr flattens a (possibly grouped) slog.Attr into fields, joining
+// group names with "." so nested groups render the same way nested
+// structs already do via render.Render.
+func addSlogAttr(fields field.M, groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		nested := append(append([]string{}, groups...), a.Key)
+		for _, ga := range a.Value.Group() {
+			addSlogAttr(fields, nested, ga)
+		}
+		return
+	}
+	key := a.Key
+	if len(groups) > 0 {
+		key = strings.Join(append(groups, a.Key), ".")
+	}
+	fields[key] = a.Value.Any()
+}
+
+// slogToLevel maps a slog.Level onto this package's Level, collapsing
+// slog's Warn level into Info since Level has no warning tier.
+func slogToLevel(l slog.Level) Level {
+	switch {
+	case l >= slog.LevelError:
+		return ErrorLevel
+	case l < slog.LevelInfo:
+		return DebugLevel
+	default:
+		return InfoLevel
+	}
+}
+
+var _ slog.Handler = (*slogHandler)(nil)
@@ -0,0 +1,97 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/kanisterio/kanister/pkg/field"
+)
+
+type stringerVal struct{ s string }
+
+func (v stringerVal) String() string { return v.s }
+
+func TestAddSlogAttrFlattensGroups(t *testing.T) {
+	fields := field.M{}
+	addSlogAttr(fields, nil, slog.Group("req",
+		slog.Int("status", 200),
+		slog.Group("inner", slog.String("id", "abc")),
+	))
+
+	if fields["req.status"] != int64(200) {
+		t.Errorf("expected req.status=200, got %v", fields["req.status"])
+	}
+	if fields["req.inner.id"] != "abc" {
+		t.Errorf("expected req.inner.id=abc, got %v", fields["req.inner.id"])
+	}
+}
+
+func TestAddSlogAttrPreservesValueTypes(t *testing.T) {
+	fields := field.M{}
+	addSlogAttr(fields, nil, slog.Any("err", errors.New("boom")))
+	addSlogAttr(fields, nil, slog.Any("name", stringerVal{"svc"}))
+
+	if _, ok := fields["err"].(error); !ok {
+		t.Errorf("expected err field to remain an error, got %T", fields["err"])
+	}
+	if _, ok := fields["name"].(stringerVal); !ok {
+		t.Errorf("expected name field to remain a fmt.Stringer, got %T", fields["name"])
+	}
+}
+
+func TestSlogToLevel(t *testing.T) {
+	cases := map[slog.Level]Level{
+		slog.LevelDebug: DebugLevel,
+		slog.LevelInfo:  InfoLevel,
+		slog.LevelWarn:  InfoLevel,
+		slog.LevelError: ErrorLevel,
+	}
+	for in, want := range cases {
+		if got := slogToLevel(in); got != want {
+			t.Errorf("slogToLevel(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestSlogHandlerMatchesDirectPrintOutput(t *testing.T) {
+	SetFormatter(JSONFormat)
+	defer SetFormatter(TextFormat)
+	old := log.Out
+	defer log.SetOutput(old)
+
+	var direct, viaSlog bytes.Buffer
+
+	log.SetOutput(&direct)
+	Info().Print("handled", field.M{"status": 200})
+
+	log.SetOutput(&viaSlog)
+	slog.New(NewSlogHandler(InfoLevel)).Info("handled", "status", 200)
+
+	var directOut, slogOut map[string]interface{}
+	if err := json.Unmarshal(direct.Bytes(), &directOut); err != nil {
+		t.Fatalf("unmarshal direct output: %v", err)
+	}
+	if err := json.Unmarshal(viaSlog.Bytes(), &slogOut); err != nil {
+		t.Fatalf("unmarshal slog output: %v", err)
+	}
+
+	for _, key := range []string{"msg", "level", "status"} {
+		if directOut[key] != slogOut[key] {
+			t.Errorf("field %q mismatch: direct=%v slog=%v", key, directOut[key], slogOut[key])
+		}
+	}
+}
+
+func TestSlogHandlerEnabled(t *testing.T) {
+	h := NewSlogHandler(InfoLevel)
+	if h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("expected debug records to be disabled at InfoLevel")
+	}
+	if !h.Enabled(context.Background(), slog.LevelError) {
+		t.Error("expected error records to be enabled at InfoLevel")
+	}
+}
@@ -0,0 +1,60 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPrintInjectsTraceContext(t *testing.T) {
+	SetFormatter(JSONFormat)
+	defer SetFormatter(TextFormat)
+	old := log.Out
+	defer log.SetOutput(old)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	WithContext(ctx).Print("handled")
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out["trace_id"] != sc.TraceID().String() {
+		t.Errorf("expected trace_id %q, got %v", sc.TraceID().String(), out["trace_id"])
+	}
+	if out["span_id"] != sc.SpanID().String() {
+		t.Errorf("expected span_id %q, got %v", sc.SpanID().String(), out["span_id"])
+	}
+}
+
+func TestPrintOmitsTraceContextWithoutSpan(t *testing.T) {
+	SetFormatter(JSONFormat)
+	defer SetFormatter(TextFormat)
+	old := log.Out
+	defer log.SetOutput(old)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+
+	WithContext(context.Background()).Print("handled")
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if _, ok := out["trace_id"]; ok {
+		t.Errorf("expected no trace_id without a span context, got %v", out["trace_id"])
+	}
+}